@@ -0,0 +1,34 @@
+// Command step-ca runs an online certificate authority for secure,
+// automated certificate management.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/smallstep/certificates/commands"
+	"github.com/smallstep/certificates/internal/version"
+	"github.com/urfave/cli"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "step-ca"
+	app.HelpName = "step-ca"
+	app.Usage = "an online certificate authority for secure, automated certificate management"
+	app.Version = version.Get().Version
+	app.HideVersion = true
+	app.Commands = commands.Commands
+	app.Action = commands.AppCommand.Action
+	app.Flags = commands.AppCommand.Flags
+	app.UsageText = commands.AppCommand.UsageText
+
+	if err := app.Run(os.Args); err != nil {
+		if os.Getenv("STEPDEBUG") == "1" {
+			fmt.Fprintf(os.Stderr, "%+v\n", err)
+		} else {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"unicode"
 
 	"github.com/pkg/errors"
 	"github.com/smallstep/certificates/authority/config"
 	"github.com/smallstep/certificates/ca"
+	"github.com/smallstep/certificates/internal/version"
 	"github.com/smallstep/certificates/pki"
 	"github.com/urfave/cli"
 	"go.step.sm/cli-utils/errs"
@@ -58,26 +60,76 @@ certificate issuer private key used in the RA mode.`,
 			Usage:  "token used to enable the linked ca.",
 			EnvVar: "STEP_CA_TOKEN",
 		},
+		cli.StringFlag{
+			Name: "onboarding-token",
+			Usage: `token used to bootstrap a new authority from the onboarding
+guide. When passed without an existing <config>, 'step-ca' will fetch the
+authority configuration from --onboarding-url, generate it under
+$STEPPATH, and start the CA.`,
+		},
+		cli.StringFlag{
+			Name:   "onboarding-url",
+			Usage:  "<url> of the onboarding guide used with --onboarding-token.",
+			EnvVar: "STEP_CA_ONBOARDING_URL",
+			Value:  defaultOnboardingURL,
+		},
+		cli.BoolFlag{
+			Name:  "version",
+			Usage: "print the current version of step-ca and exit.",
+		},
 	},
 }
 
 // AppAction is the action used when the top command runs.
 func appAction(ctx *cli.Context) error {
+	if ctx.Bool("version") {
+		fmt.Println(version.Get().String())
+		return nil
+	}
+
 	sshHostPassFile := ctx.String("ssh-host-password-file")
 	sshUserPassFile := ctx.String("ssh-user-password-file")
 	issuerPassFile := ctx.String("issuer-password-file")
 	resolver := ctx.String("resolver")
 	token := ctx.String("token")
+	onboardingToken := ctx.String("onboarding-token")
+	onboardingURL := ctx.String("onboarding-url")
 
-	// If zero cmd line args show help, if >1 cmd line args show error.
-	if ctx.NArg() == 0 {
+	// If zero cmd line args show help, if >1 cmd line args show error. The
+	// <config> argument may be omitted when --onboarding-token is passed.
+	if ctx.NArg() == 0 && onboardingToken == "" {
 		return cli.ShowAppHelp(ctx)
 	}
-	if err := errs.NumberOfArguments(ctx, 1); err != nil {
+	if err := errs.NumberOfArguments(ctx, 1); err != nil && (onboardingToken == "" || ctx.NArg() > 1) {
 		return err
 	}
 
-	configFile := ctx.Args().Get(0)
+	var configFile string
+	if ctx.NArg() == 1 {
+		configFile = ctx.Args().Get(0)
+		if onboardingToken == "" && looksLikeOnboardingToken(configFile) {
+			if _, err := os.Stat(configFile); os.IsNotExist(err) {
+				onboardingToken, configFile = configFile, ""
+			}
+		} else if onboardingToken != "" {
+			if _, err := os.Stat(configFile); err == nil {
+				onboardingToken = ""
+			}
+		}
+	}
+
+	// onboardingPassword is the password used to encrypt the intermediate
+	// private key generated during bootstrap. It must be reused below
+	// instead of loadPassword, which would otherwise fail on non-TTY stdin
+	// or prompt for a password that was never used to encrypt anything.
+	var onboardingPassword []byte
+	if onboardingToken != "" {
+		var err error
+		if configFile, onboardingPassword, err = bootstrapOnboarding(onboardingURL, onboardingToken); err != nil {
+			fatal(err)
+		}
+	}
+
 	cfg, err := config.LoadConfiguration(configFile)
 	if err != nil {
 		fatal(err)
@@ -95,7 +147,12 @@ To get a linked authority token:
 		}
 	}
 
-	password := []byte("123456")
+	password := onboardingPassword
+	if password == nil {
+		if password, err = loadPassword(ctx.String("password-file")); err != nil {
+			fatal(err)
+		}
+	}
 
 	var sshHostPassword []byte
 	if sshHostPassFile != "" {
@@ -147,6 +204,23 @@ To get a linked authority token:
 	return nil
 }
 
+// looksLikeOnboardingToken reports whether arg could plausibly be an
+// onboarding token rather than a mistyped configuration file path. Paths
+// (those containing a separator or ending in a recognized config extension)
+// are never reinterpreted as a token, so a typo in <config> still surfaces
+// as a clear file-not-found error instead of a network call to the
+// onboarding guide.
+func looksLikeOnboardingToken(arg string) bool {
+	if strings.ContainsRune(arg, filepath.Separator) || strings.ContainsRune(arg, '/') {
+		return false
+	}
+	switch filepath.Ext(arg) {
+	case ".json", ".yaml", ".yml":
+		return false
+	}
+	return true
+}
+
 // fatal writes the passed error on the standard error and exits with the exit
 // code 1. If the environment variable STEPDEBUG is set to 1 it shows the
 // stack trace of the error.
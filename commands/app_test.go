@@ -0,0 +1,24 @@
+package commands
+
+import "testing"
+
+func TestLooksLikeOnboardingToken(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{"abc123", true},
+		{"deadbeefcafe", true},
+		{"ca.json", false},
+		{"ca.yaml", false},
+		{"ca.yml", false},
+		{"config/ca.json", false},
+		{"./ca.json", false},
+		{"/etc/step-ca/ca.json", false},
+	}
+	for _, tt := range tests {
+		if got := looksLikeOnboardingToken(tt.arg); got != tt.want {
+			t.Errorf("looksLikeOnboardingToken(%q) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+}
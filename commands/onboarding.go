@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/smallstep/certificates/pki"
+	"go.step.sm/crypto/randutil"
+)
+
+// defaultOnboardingURL is used when neither --onboarding-url nor the
+// STEP_CA_ONBOARDING_URL environment variable is set.
+const defaultOnboardingURL = "https://api.smallstep.com"
+
+// onboardingPayload is returned by the onboarding guide and describes the
+// authority that should be generated locally.
+type onboardingPayload struct {
+	Name    string `json:"name"`
+	DNS     string `json:"dns"`
+	Address string `json:"address"`
+}
+
+// onboardingCompletion is posted back to the onboarding guide once the
+// authority has been generated, so it can validate the deployment.
+type onboardingCompletion struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
+// OnboardingClient talks to the onboarding guide API used by `step-ca start
+// --onboarding-token` to bootstrap a new authority from a single token.
+type OnboardingClient struct {
+	base         string
+	client       *http.Client
+	retryBackoff time.Duration
+}
+
+// newOnboardingClient creates an OnboardingClient rooted at base.
+func newOnboardingClient(base string) *OnboardingClient {
+	return &OnboardingClient{
+		base:         strings.TrimSuffix(base, "/"),
+		client:       &http.Client{Timeout: 30 * time.Second},
+		retryBackoff: 500 * time.Millisecond,
+	}
+}
+
+// Get retrieves the onboarding payload for the given token.
+func (c *OnboardingClient) Get(token string) (*onboardingPayload, error) {
+	url := fmt.Sprintf("%s/onboarding/%s", c.base, token)
+	var payload onboardingPayload
+	err := c.retry(func() error {
+		resp, err := c.client.Get(url)
+		if err != nil {
+			return retryableError{err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return statusError(resp)
+		}
+		return json.NewDecoder(resp.Body).Decode(&payload)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error fetching onboarding configuration")
+	}
+	return &payload, nil
+}
+
+// Complete reports the fingerprint of the newly generated root certificate
+// back to the onboarding guide for the given token.
+func (c *OnboardingClient) Complete(token, fingerprint string) error {
+	url := fmt.Sprintf("%s/onboarding/%s/complete", c.base, token)
+	body, err := json.Marshal(onboardingCompletion{Fingerprint: fingerprint})
+	if err != nil {
+		return errors.Wrap(err, "error marshaling onboarding completion")
+	}
+	err = c.retry(func() error {
+		resp, err := c.client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return retryableError{err}
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return statusError(resp)
+		}
+		return nil
+	})
+	return errors.Wrap(err, "error completing onboarding")
+}
+
+// retryableError marks an error returned by an onboarding HTTP call as safe
+// to retry, i.e. a transport-level failure or a 5xx response. Anything else
+// (a 4xx like an invalid or expired token) is deterministic and returned to
+// the caller immediately.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+// retry runs fn up to 5 times, backing off exponentially starting at 500ms.
+// It stops as soon as fn returns a non-retryable error, and returns the
+// last underlying error if every attempt fails.
+func (c *OnboardingClient) retry(fn func() error) error {
+	const attempts = 5
+	backoff := c.retryBackoff
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		re, ok := err.(retryableError)
+		if !ok {
+			return err
+		}
+		err = re.err
+		if i < attempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+// statusError builds an error out of a non-2xx onboarding response. 5xx
+// responses are marked retryable, 4xx responses are not.
+func statusError(resp *http.Response) error {
+	b, _ := ioutil.ReadAll(resp.Body)
+	err := errors.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	if resp.StatusCode >= 500 {
+		return retryableError{err}
+	}
+	return err
+}
+
+// bootstrapOnboarding fetches the onboarding payload for token from
+// onboardingURL, generates a new authority under $STEPPATH, and reports the
+// resulting fingerprint back to the onboarding guide. On success it returns
+// the path to the generated CA configuration along with the password used
+// to encrypt the intermediate private key, so the caller can start the CA
+// without prompting for a password that was never set.
+func bootstrapOnboarding(onboardingURL, token string) (configFile string, password []byte, err error) {
+	client := newOnboardingClient(onboardingURL)
+
+	fmt.Println("Fetching onboarding configuration...")
+	payload, err := client.Get(token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fmt.Printf("Generating authority %q...\n", payload.Name)
+	p, err := pki.New(pki.WithDeploymentType(pki.StandaloneDeploymentType))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error initializing pki")
+	}
+	if err := p.SetAddress(payload.Address); err != nil {
+		return "", nil, errors.Wrap(err, "error setting address")
+	}
+	if err := p.SetDNSNames(strings.Split(payload.DNS, ",")); err != nil {
+		return "", nil, errors.Wrap(err, "error setting dns names")
+	}
+
+	pass, err := randutil.Alphanumeric(32)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error generating password")
+	}
+	password = []byte(pass)
+
+	rootCrt, rootKey, err := p.GenerateRootCertificate(payload.Name, payload.Name, "Root CA", password)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error generating root certificate")
+	}
+	fingerprint, err := p.WriteRootCertificate(rootCrt, rootKey)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error writing root certificate")
+	}
+
+	if err := p.GenerateKeyPairs(password); err != nil {
+		return "", nil, errors.Wrap(err, "error generating key pairs")
+	}
+
+	config, err := p.GenerateConfig()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error generating configuration")
+	}
+	b, err := json.MarshalIndent(config, "", "   ")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error marshaling configuration")
+	}
+	configFile = p.GetConfigPath()
+	if err := ioutil.WriteFile(configFile, b, 0644); err != nil {
+		return "", nil, errors.Wrapf(err, "error writing %s", configFile)
+	}
+
+	fmt.Println("Reporting completion to the onboarding guide...")
+	if err := client.Complete(token, fingerprint); err != nil {
+		return "", nil, err
+	}
+
+	fmt.Println("Onboarding complete.")
+	return configFile, password, nil
+}
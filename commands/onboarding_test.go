@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOnboardingClient_Get(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/onboarding/abc123" {
+				t.Fatalf("unexpected path %s", r.URL.Path)
+			}
+			json.NewEncoder(w).Encode(onboardingPayload{
+				Name:    "test-ca",
+				DNS:     "127.0.0.1,localhost",
+				Address: ":443",
+			})
+		}))
+		defer srv.Close()
+
+		payload, err := newOnboardingClient(srv.URL).Get("abc123")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payload.Name != "test-ca" || payload.DNS != "127.0.0.1,localhost" || payload.Address != ":443" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+	})
+
+	t.Run("not found is not retried", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		if _, err := newOnboardingClient(srv.URL).Get("bad-token"); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if got := atomic.LoadInt32(&calls); got != 1 {
+			t.Fatalf("expected exactly 1 call for a non-retryable status, got %d", got)
+		}
+	})
+
+	t.Run("server error is retried", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(onboardingPayload{Name: "test-ca"})
+		}))
+		defer srv.Close()
+
+		client := newOnboardingClient(srv.URL)
+		client.retryBackoff = 0
+
+		payload, err := client.Get("abc123")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payload.Name != "test-ca" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		if got := atomic.LoadInt32(&calls); got != 3 {
+			t.Fatalf("expected 3 calls, got %d", got)
+		}
+	})
+}
+
+func TestOnboardingClient_Complete(t *testing.T) {
+	var gotBody onboardingCompletion
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/onboarding/abc123/complete" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("unexpected error decoding body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	if err := newOnboardingClient(srv.URL).Complete("abc123", "deadbeef"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody.Fingerprint != "deadbeef" {
+		t.Fatalf("unexpected fingerprint: %s", gotBody.Fingerprint)
+	}
+}
+
+func TestOnboardingClient_statusError(t *testing.T) {
+	tests := []struct {
+		status      int
+		wantRetried bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusNotFound, false},
+		{http.StatusInternalServerError, true},
+		{http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d", tt.status), func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Body: http.NoBody}
+			err := statusError(resp)
+			_, retried := err.(retryableError)
+			if retried != tt.wantRetried {
+				t.Fatalf("status %d: retryable=%v, want %v", tt.status, retried, tt.wantRetried)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"go.step.sm/cli-utils/ui"
+	"golang.org/x/term"
+)
+
+// promptPassword reads the password used to decrypt the intermediate
+// private key. It is a variable so it can be swapped out in tests.
+var promptPassword = func() ([]byte, error) {
+	return ui.PromptPassword("Password with which to decrypt the intermediate private key")
+}
+
+// isTerminal reports whether stdin is a terminal. It is a variable so it
+// can be swapped out in tests.
+var isTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// loadPassword returns the password used to decrypt the intermediate
+// private key. It reads it from --password-file when given, otherwise it
+// prompts interactively if stdin is a terminal, and fails otherwise.
+func loadPassword(passwordFile string) ([]byte, error) {
+	if passwordFile != "" {
+		b, err := ioutil.ReadFile(passwordFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %s", passwordFile)
+		}
+		return bytes.TrimRightFunc(b, unicode.IsSpace), nil
+	}
+
+	if !isTerminal() {
+		return nil, errors.New("'step-ca' requires the '--password-file' flag when stdin is not a terminal")
+	}
+
+	password, err := promptPassword()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading password")
+	}
+	return password, nil
+}
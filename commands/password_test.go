@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadPassword_fromFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("s3cr3t\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.Close()
+
+	password, err := loadPassword(f.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(password) != "s3cr3t" {
+		t.Fatalf("got %q, want %q", password, "s3cr3t")
+	}
+}
+
+func TestLoadPassword_missingFile(t *testing.T) {
+	if _, err := loadPassword("/does/not/exist"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestLoadPassword_nonTTYWithoutFile(t *testing.T) {
+	oldTerminal, oldPrompt := isTerminal, promptPassword
+	defer func() { isTerminal, promptPassword = oldTerminal, oldPrompt }()
+
+	isTerminal = func() bool { return false }
+	promptPassword = func() ([]byte, error) {
+		t.Fatal("promptPassword should not be called when stdin is not a terminal")
+		return nil, nil
+	}
+
+	if _, err := loadPassword(""); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestLoadPassword_interactivePrompt(t *testing.T) {
+	oldTerminal, oldPrompt := isTerminal, promptPassword
+	defer func() { isTerminal, promptPassword = oldTerminal, oldPrompt }()
+
+	isTerminal = func() bool { return true }
+	promptPassword = func() ([]byte, error) {
+		return []byte("prompted"), nil
+	}
+
+	password, err := loadPassword("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(password) != "prompted" {
+		t.Fatalf("got %q, want %q", password, "prompted")
+	}
+}
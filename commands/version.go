@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/smallstep/certificates/internal/version"
+	"github.com/urfave/cli"
+)
+
+// VersionCommand is the action used to print the current version.
+var VersionCommand = cli.Command{
+	Name:   "version",
+	Usage:  "display the current version of step-ca",
+	Action: versionAction,
+}
+
+func versionAction(ctx *cli.Context) error {
+	fmt.Println(version.Get().String())
+	return nil
+}
+
+// Commands contains all the top-level CLI commands exposed by step-ca. It
+// should be used by cmd/step-ca to populate the cli.App's Commands list,
+// alongside AppCommand as the app's default action.
+var Commands = []cli.Command{
+	AppCommand,
+	VersionCommand,
+}
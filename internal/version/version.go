@@ -0,0 +1,57 @@
+// Package version holds the build metadata for step-ca binaries. Version,
+// Commit, and BuildTime are populated at build time via -ldflags, e.g.:
+//
+//	go build -ldflags "-X github.com/smallstep/certificates/internal/version.Version=v1.2.3 \
+//		-X github.com/smallstep/certificates/internal/version.Commit=$(git rev-parse HEAD) \
+//		-X github.com/smallstep/certificates/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+)
+
+// Build time information, set via -ldflags.
+var (
+	Version   = "N/A"
+	Commit    = "N/A"
+	BuildTime = "N/A"
+)
+
+// Info describes the build metadata of a step-ca binary.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+	GoOS      string `json:"goOS"`
+	GoArch    string `json:"goArch"`
+}
+
+// Get returns the Info for the running binary.
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		GoOS:      runtime.GOOS,
+		GoArch:    runtime.GOARCH,
+	}
+}
+
+// String returns a human readable representation of i.
+func (i Info) String() string {
+	return fmt.Sprintf("Version: %s\nCommit: %s\nBuild Time: %s\nGo Version: %s\nPlatform: %s/%s",
+		i.Version, i.Commit, i.BuildTime, i.GoVersion, i.GoOS, i.GoArch)
+}
+
+// Handler writes the running binary's Info as JSON. Mount it at "/version"
+// on the CA's API router so operators can query the build of a running
+// instance the same way they can with the CLI.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Get())
+}
@@ -0,0 +1,29 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	Version, Commit, BuildTime = "v1.2.3", "abcdef", "2026-07-25T00:00:00Z"
+	defer func() { Version, Commit, BuildTime = "N/A", "N/A", "N/A" }()
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rec := httptest.NewRecorder()
+	Handler(rec, req)
+
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("unexpected content type: %s", got)
+	}
+
+	var info Info
+	if err := json.NewDecoder(rec.Body).Decode(&info); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if info.Version != "v1.2.3" || info.Commit != "abcdef" || info.BuildTime != "2026-07-25T00:00:00Z" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}